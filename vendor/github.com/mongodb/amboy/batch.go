@@ -0,0 +1,15 @@
+package amboy
+
+import "context"
+
+// BatchCompleter is an optional interface that a Queue implementation may
+// satisfy to mark many jobs complete in a single round trip, rather than
+// requiring one Complete call per job. Queues backed by a remote store
+// (e.g. MongoDB) should implement this via a single bulk write; queues that
+// do not implement this interface fall back to completing jobs individually.
+type BatchCompleter interface {
+	// CompleteMany marks all of the given jobs complete. It returns one
+	// error per job, in the same order as the input slice, with a nil
+	// entry for jobs that completed successfully.
+	CompleteMany(ctx context.Context, jobs []Job) []error
+}
@@ -0,0 +1,67 @@
+package amboy
+
+import (
+	"context"
+	"time"
+)
+
+// FeedbackLevel classifies a single FeedbackEntry as info, warning or
+// error.
+type FeedbackLevel string
+
+const (
+	FeedbackLevelInfo  FeedbackLevel = "info"
+	FeedbackLevelWarn  FeedbackLevel = "warn"
+	FeedbackLevelError FeedbackLevel = "error"
+)
+
+// FeedbackEntry is a single piece of structured progress or log output
+// emitted by a running job.
+type FeedbackEntry struct {
+	Level   FeedbackLevel
+	Time    time.Time
+	Message string
+}
+
+// Feedback is a streaming sink for structured progress and log messages
+// from inside a running job, for jobs whose work is long enough that
+// operators want to tail it rather than wait for a final result.
+type Feedback interface {
+	Info(message string)
+	Warn(message string)
+	Error(message string)
+}
+
+// FeedbackJob is an optional interface that a Job can implement to receive
+// a Feedback sink before it runs. Runners that support feedback (e.g.
+// pool.abortablePool) detect this interface and call SetFeedback before
+// invoking Run.
+type FeedbackJob interface {
+	SetFeedback(Feedback)
+}
+
+// FeedbackPersister is an optional interface a Job can implement, alongside
+// FeedbackJob, to retain its own feedback history as part of its persisted
+// state. Runners that support feedback forward every recorded entry here in
+// real time, so that whatever already persists the job (e.g. a remote
+// queue's backend, which saves the job on completion) captures its feedback
+// history too. JobFeedback and StreamJobFeedback do not read entries back
+// from the job: they return only what the runner that ran it still holds in
+// memory.
+type FeedbackPersister interface {
+	AddFeedback(FeedbackEntry)
+}
+
+// FeedbackProvider is an optional interface that a Runner can implement to
+// expose the feedback emitted by the jobs it has run, so that
+// management.Manager can read and tail it without depending on the runner
+// implementation directly.
+type FeedbackProvider interface {
+	// JobFeedback returns every feedback entry recorded for id at or
+	// after since.
+	JobFeedback(ctx context.Context, id string, since time.Time) ([]FeedbackEntry, error)
+	// StreamJobFeedback returns a channel of feedback entries for id as
+	// they are recorded. The channel is closed when the job finishes or
+	// ctx is done.
+	StreamJobFeedback(ctx context.Context, id string) (<-chan FeedbackEntry, error)
+}
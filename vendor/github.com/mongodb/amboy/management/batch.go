@@ -0,0 +1,193 @@
+package management
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+)
+
+// DefaultBatchSize is the number of jobs of a given type that BatchCompleter
+// groups together before flushing, when the caller does not specify one.
+const DefaultBatchSize = 100
+
+// defaultBatchWorkers bounds the fallback worker pool used when the
+// underlying queue does not implement amboy.BatchCompleter.
+const defaultBatchWorkers = 8
+
+// BatchCompleterOptions configure the batching behavior of a BatchCompleter.
+type BatchCompleterOptions struct {
+	// BatchSize is the number of jobs of a single type that are grouped
+	// together before being flushed. Defaults to DefaultBatchSize.
+	BatchSize int
+	// Workers bounds the number of jobs completed concurrently when the
+	// queue falls back to completing jobs individually. Defaults to
+	// defaultBatchWorkers.
+	Workers int
+}
+
+func (o *BatchCompleterOptions) resolve() {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.Workers <= 0 {
+		o.Workers = defaultBatchWorkers
+	}
+}
+
+// CompleterMetrics reports the amount of work a BatchCompleter has done,
+// so that operators can observe the reduction in round trips to the queue's
+// backing store.
+type CompleterMetrics struct {
+	Enqueued   int64
+	Flushed    int64
+	Batches    int64
+	AvgLatency time.Duration
+}
+
+// BatchCompleter groups jobs by type into fixed-size buckets and flushes
+// them via a single round trip to the queue, rather than issuing one
+// queue.Complete call per job. If the queue implements amboy.BatchCompleter,
+// flushing uses that bulk path; otherwise it falls back to a bounded worker
+// pool that completes jobs individually.
+type BatchCompleter struct {
+	queue   amboy.Queue
+	opts    BatchCompleterOptions
+	batcher amboy.BatchCompleter
+
+	mu      sync.Mutex
+	buckets map[string][]amboy.Job
+
+	metricsMu sync.Mutex
+	metrics   CompleterMetrics
+}
+
+// NewBatchCompleter constructs a BatchCompleter for the given queue.
+func NewBatchCompleter(q amboy.Queue, opts BatchCompleterOptions) *BatchCompleter {
+	opts.resolve()
+
+	bc := &BatchCompleter{
+		queue:   q,
+		opts:    opts,
+		buckets: map[string][]amboy.Job{},
+	}
+
+	bc.batcher, _ = q.(amboy.BatchCompleter)
+
+	return bc
+}
+
+// Add stages a job for completion. Once the bucket for the job's type
+// reaches the configured batch size, it is flushed automatically.
+//
+// A non-nil error here may cover more than j: flushing a full bucket
+// reports one error per job that failed, already attributed to that job's
+// own ID by flushBucket, not to j, which merely happened to trigger the
+// flush.
+func (bc *BatchCompleter) Add(ctx context.Context, j amboy.Job) error {
+	bc.mu.Lock()
+	jobType := j.Type().Name
+	bc.buckets[jobType] = append(bc.buckets[jobType], j)
+	full := len(bc.buckets[jobType]) >= bc.opts.BatchSize
+	var bucket []amboy.Job
+	if full {
+		bucket = bc.buckets[jobType]
+		delete(bc.buckets, jobType)
+	}
+	bc.mu.Unlock()
+
+	bc.metricsMu.Lock()
+	bc.metrics.Enqueued++
+	bc.metricsMu.Unlock()
+
+	if !full {
+		return nil
+	}
+
+	return bc.flushBucket(ctx, bucket)
+}
+
+// Flush completes every job currently staged, regardless of bucket size.
+func (bc *BatchCompleter) Flush(ctx context.Context) error {
+	bc.mu.Lock()
+	buckets := bc.buckets
+	bc.buckets = map[string][]amboy.Job{}
+	bc.mu.Unlock()
+
+	catcher := grip.NewBasicCatcher()
+	for _, bucket := range buckets {
+		catcher.Add(bc.flushBucket(ctx, bucket))
+	}
+
+	return catcher.Resolve()
+}
+
+// Metrics returns a snapshot of the completer's activity.
+func (bc *BatchCompleter) Metrics() CompleterMetrics {
+	bc.metricsMu.Lock()
+	defer bc.metricsMu.Unlock()
+
+	return bc.metrics
+}
+
+func (bc *BatchCompleter) flushBucket(ctx context.Context, bucket []amboy.Job) error {
+	if len(bucket) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+
+	var errs []error
+	if bc.batcher != nil {
+		errs = bc.batcher.CompleteMany(ctx, bucket)
+	} else {
+		errs = bc.completeIndividually(ctx, bucket)
+	}
+
+	bc.metricsMu.Lock()
+	bc.metrics.Flushed += int64(len(bucket))
+	bc.metrics.Batches++
+	elapsed := time.Since(start)
+	if bc.metrics.Batches == 1 {
+		bc.metrics.AvgLatency = elapsed
+	} else {
+		bc.metrics.AvgLatency = (bc.metrics.AvgLatency*time.Duration(bc.metrics.Batches-1) + elapsed) / time.Duration(bc.metrics.Batches)
+	}
+	bc.metricsMu.Unlock()
+
+	catcher := grip.NewBasicCatcher()
+	for idx, err := range errs {
+		if err == nil {
+			continue
+		}
+		catcher.Wrapf(err, "completing job '%s'", bucket[idx].ID())
+	}
+
+	return catcher.Resolve()
+}
+
+// completeIndividually marks each job in the bucket complete with a bounded
+// worker pool, used when the queue does not support bulk completion.
+func (bc *BatchCompleter) completeIndividually(ctx context.Context, bucket []amboy.Job) []error {
+	errs := make([]error, len(bucket))
+
+	sem := make(chan struct{}, bc.opts.Workers)
+	wg := sync.WaitGroup{}
+
+	for idx, j := range bucket {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, j amboy.Job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[idx] = completeJobAndRetry(ctx, bc.queue, j)
+		}(idx, j)
+	}
+
+	wg.Wait()
+
+	return errs
+}
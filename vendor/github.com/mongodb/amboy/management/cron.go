@@ -0,0 +1,133 @@
+package management
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cronFieldBounds holds the valid [min, max] range for each of the 5
+// standard cron fields, in order: minute, hour, day-of-month, month,
+// day-of-week (0 = Sunday).
+var cronFieldBounds = [5][2]int{
+	{0, 59},
+	{0, 23},
+	{1, 31},
+	{1, 12},
+	{0, 6},
+}
+
+// maxCronLookahead bounds how many minutes cronSpec.next will scan before
+// giving up, so an expression that can never match (e.g. "0 0 30 2 *")
+// fails fast instead of scanning forever.
+const maxCronLookahead = 4 * 366 * 24 * 60
+
+type cronField struct {
+	allowed map[int]struct{}
+}
+
+func (f cronField) match(v int) bool {
+	_, ok := f.allowed[v]
+	return ok
+}
+
+// cronSpec is a parsed 5-field cron expression (minute hour dom month dow),
+// supporting "*", single values, ranges ("a-b"), steps ("*/n" or "a-b/n"),
+// and comma-separated lists of any of the above.
+type cronSpec struct {
+	minute, hour, dom, month, dow cronField
+}
+
+func parseCronSpec(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("cron expression '%s' must have 5 fields (minute hour dom month dow), has %d", expr, len(fields))
+	}
+
+	parsed := make([]cronField, len(fields))
+	for i, field := range fields {
+		f, err := parseCronField(field, cronFieldBounds[i][0], cronFieldBounds[i][1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing field %d ('%s')", i, field)
+		}
+		parsed[i] = f
+	}
+
+	return &cronSpec{
+		minute: parsed[0],
+		hour:   parsed[1],
+		dom:    parsed[2],
+		month:  parsed[3],
+		dow:    parsed[4],
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	allowed := map[int]struct{}{}
+
+	for _, part := range strings.Split(field, ",") {
+		lo, hi, step := min, max, 1
+
+		valuePart := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return cronField{}, errors.Errorf("invalid step in '%s'", part)
+			}
+			valuePart = part[:idx]
+		}
+
+		switch {
+		case valuePart == "*" || valuePart == "":
+			// lo/hi already cover the full range
+		case strings.Contains(valuePart, "-"):
+			bounds := strings.SplitN(valuePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return cronField{}, errors.Errorf("invalid range start in '%s'", valuePart)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return cronField{}, errors.Errorf("invalid range end in '%s'", valuePart)
+			}
+		default:
+			v, err := strconv.Atoi(valuePart)
+			if err != nil {
+				return cronField{}, errors.Errorf("invalid value '%s'", valuePart)
+			}
+			lo, hi = v, v
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return cronField{}, errors.Errorf("value '%s' out of range [%d, %d]", part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			allowed[v] = struct{}{}
+		}
+	}
+
+	return cronField{allowed: allowed}, nil
+}
+
+// next returns the first minute-aligned time strictly after after that
+// matches the spec, or the zero time if none is found within
+// maxCronLookahead minutes.
+func (s *cronSpec) next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	for i := 0; i < maxCronLookahead; i++ {
+		if s.month.match(int(t.Month())) &&
+			s.dom.match(t.Day()) &&
+			s.dow.match(int(t.Weekday())) &&
+			s.hour.match(t.Hour()) &&
+			s.minute.match(t.Minute()) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,33 @@
+package management
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mongodb/amboy"
+)
+
+// forEachJobInfo fans a JobInfo channel out across concurrency worker
+// goroutines, invoking fn once per info. fn is called with the index of
+// the worker goroutine that is invoking it (in [0, concurrency)), so that
+// callers can mutate a per-worker accumulator shard rather than a shared
+// one; the caller is responsible for merging shards once forEachJobInfo
+// returns. forEachJobInfo blocks until infos is drained.
+func forEachJobInfo(ctx context.Context, infos <-chan amboy.JobInfo, concurrency int, fn func(shard int, info amboy.JobInfo)) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+			for info := range infos {
+				fn(shard, info)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
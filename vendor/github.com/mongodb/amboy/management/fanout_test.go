@@ -0,0 +1,96 @@
+package management
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachJobInfo(t *testing.T) {
+	ctx := context.Background()
+
+	infos := make(chan amboy.JobInfo)
+	go func() {
+		defer close(infos)
+		for i := 0; i < 1000; i++ {
+			infos <- amboy.JobInfo{
+				ID:   fmt.Sprintf("job.%d", i),
+				Type: amboy.JobType{Name: fmt.Sprintf("type.%d", i%4)},
+			}
+		}
+	}()
+
+	const concurrency = 4
+	shards := make([]map[string]int, concurrency)
+	for i := range shards {
+		shards[i] = map[string]int{}
+	}
+
+	forEachJobInfo(ctx, infos, concurrency, func(shard int, info amboy.JobInfo) {
+		shards[shard][info.Type.Name]++
+	})
+
+	total := 0
+	byType := map[string]int{}
+	for _, shard := range shards {
+		for jt, count := range shard {
+			byType[jt] += count
+			total += count
+		}
+	}
+
+	assert.Equal(t, 1000, total)
+	assert.Len(t, byType, 4)
+}
+
+func benchmarkJobInfos(n int) []amboy.JobInfo {
+	out := make([]amboy.JobInfo, n)
+	for i := 0; i < n; i++ {
+		out[i] = amboy.JobInfo{
+			ID:   fmt.Sprintf("job.%d", i),
+			Type: amboy.JobType{Name: fmt.Sprintf("type.%d", i%16)},
+			Time: amboy.JobTimeInfo{
+				Created: time.Now(),
+			},
+		}
+	}
+	return out
+}
+
+// BenchmarkForEachJobInfo demonstrates how scanning a large JobInfo stream
+// scales with concurrency, which is the whole point of fanning the scan out
+// across worker goroutines instead of draining it on a single goroutine.
+func BenchmarkForEachJobInfo(b *testing.B) {
+	const numInfos = 1000000
+	infos := benchmarkJobInfos(numInfos)
+
+	for _, concurrency := range []int{1, 2, 4, 8, 16} {
+		concurrency := concurrency
+		b.Run(fmt.Sprintf("Concurrency%d", concurrency), func(b *testing.B) {
+			ctx := context.Background()
+
+			for i := 0; i < b.N; i++ {
+				ch := make(chan amboy.JobInfo)
+				go func() {
+					defer close(ch)
+					for _, info := range infos {
+						ch <- info
+					}
+				}()
+
+				shards := make([]map[string]int, concurrency)
+				for j := range shards {
+					shards[j] = map[string]int{}
+				}
+
+				forEachJobInfo(ctx, ch, concurrency, func(shard int, info amboy.JobInfo) {
+					shards[shard][info.Type.Name]++
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/pkg/errors"
+)
+
+// feedbackProvider returns the queue's runner as an amboy.FeedbackProvider,
+// if it implements one. Runners like pool.abortablePool implement this
+// optionally, the same way queues optionally implement
+// amboy.BatchCompleter.
+func (m *queueManager) feedbackProvider() (amboy.FeedbackProvider, bool) {
+	provider, ok := m.queue.Runner().(amboy.FeedbackProvider)
+	return provider, ok
+}
+
+// JobFeedback returns the feedback entries recorded for the job with the
+// given ID at or after since, for runners that support per-job feedback.
+func (m *queueManager) JobFeedback(ctx context.Context, id string, since time.Time) ([]amboy.FeedbackEntry, error) {
+	provider, ok := m.feedbackProvider()
+	if !ok {
+		return nil, errors.New("queue's runner does not support job feedback")
+	}
+
+	entries, err := provider.JobFeedback(ctx, id, since)
+	return entries, errors.Wrapf(err, "getting feedback for job '%s'", id)
+}
+
+// StreamJobFeedback returns a channel of feedback entries for the job with
+// the given ID as they are recorded, for runners that support per-job
+// feedback. The channel is closed once ctx is done or the job finishes,
+// whichever happens first.
+func (m *queueManager) StreamJobFeedback(ctx context.Context, id string) (<-chan amboy.FeedbackEntry, error) {
+	provider, ok := m.feedbackProvider()
+	if !ok {
+		return nil, errors.New("queue's runner does not support job feedback")
+	}
+
+	ch, err := provider.StreamJobFeedback(ctx, id)
+	return ch, errors.Wrapf(err, "streaming feedback for job '%s'", id)
+}
+
+// feedbackErrorFor returns the message of the most recent error-level
+// feedback entry recorded for id, if the caller opted in to
+// QueueManagerOptions.IncludeFeedbackErrors and the queue's runner supports
+// feedback and has one. It returns false unconditionally otherwise, so
+// RecentErrors and RecentJobErrors fall back to info.Status.Errors, which is
+// their existing, unchanged default behavior.
+func (m *queueManager) feedbackErrorFor(ctx context.Context, id string) (string, bool) {
+	if !m.opts.IncludeFeedbackErrors {
+		return "", false
+	}
+
+	return m.lastFeedbackError(ctx, id)
+}
+
+// lastFeedbackError returns the message of the most recent error-level
+// feedback entry recorded for id, if the queue's runner supports feedback
+// and has one.
+func (m *queueManager) lastFeedbackError(ctx context.Context, id string) (string, bool) {
+	provider, ok := m.feedbackProvider()
+	if !ok {
+		return "", false
+	}
+
+	entries, err := provider.JobFeedback(ctx, id, time.Time{})
+	if err != nil {
+		return "", false
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Level == amboy.FeedbackLevelError {
+			return entries[i].Message, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,42 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/amboy"
+)
+
+// Manager describes a generic interface for managing and inspecting queues
+// through the information reported by amboy.Queue.JobInfo, without
+// requiring direct access to the queue's implementation. NewQueueManager
+// returns the canonical implementation of this interface.
+type Manager interface {
+	JobStatus(ctx context.Context, f StatusFilter) (*JobStatusReport, error)
+	RecentTiming(ctx context.Context, window time.Duration, f RuntimeFilter) (*JobRuntimeReport, error)
+	JobIDsByState(ctx context.Context, jobType string, f StatusFilter) (*JobReportIDs, error)
+	RecentErrors(ctx context.Context, window time.Duration, f ErrorFilter) (*JobErrorsReport, error)
+	RecentJobErrors(ctx context.Context, jobType string, window time.Duration, f ErrorFilter) (*JobErrorsReport, error)
+
+	CompleteJob(ctx context.Context, id string) error
+	CompleteJobs(ctx context.Context, f StatusFilter) error
+	CompleteJobsByType(ctx context.Context, f StatusFilter, jobType string) error
+	CompleteJobsByPattern(ctx context.Context, f StatusFilter, pattern string) error
+
+	// PruneJobs marks jobs matching f complete if they finished more than
+	// olderThan ago. See TTLReaper for the equivalent behavior run on a
+	// timer rather than triggered manually.
+	PruneJobs(ctx context.Context, f StatusFilter, olderThan time.Duration) (int, error)
+
+	// EnqueueAt adds j to the queue stamped with a WaitUntil time, so it
+	// isn't run until that time has passed. See Scheduler for running
+	// jobs on a cron or fixed interval instead of a single delay.
+	EnqueueAt(ctx context.Context, j amboy.Job, runAt time.Time) error
+
+	// JobFeedback returns the feedback entries recorded for id since the
+	// given time, if the underlying queue's runner supports feedback.
+	JobFeedback(ctx context.Context, id string, since time.Time) ([]amboy.FeedbackEntry, error)
+	// StreamJobFeedback returns a channel of feedback entries recorded
+	// for id, if the underlying queue's runner supports feedback.
+	StreamJobFeedback(ctx context.Context, id string) (<-chan amboy.FeedbackEntry, error)
+}
@@ -3,6 +3,7 @@ package management
 import (
 	"context"
 	"regexp"
+	"runtime"
 	"time"
 
 	"github.com/mongodb/amboy"
@@ -12,6 +13,28 @@ import (
 
 type queueManager struct {
 	queue amboy.Queue
+	opts  QueueManagerOptions
+}
+
+// QueueManagerOptions configure the behavior of a Manager built by
+// NewQueueManager.
+type QueueManagerOptions struct {
+	// Concurrency is the number of worker goroutines used to scan a
+	// queue's JobInfo when building a report. Defaults to
+	// runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// IncludeFeedbackErrors opts in to substituting a job's last
+	// error-level feedback entry for info.Status.Errors in RecentErrors
+	// and RecentJobErrors, for runners that support amboy.FeedbackProvider.
+	// Defaults to false, so existing callers see unchanged report output.
+	IncludeFeedbackErrors bool
+}
+
+func (o *QueueManagerOptions) resolve() {
+	if o.Concurrency <= 0 {
+		o.Concurrency = runtime.GOMAXPROCS(0)
+	}
 }
 
 // NewQueueManager returns a Manager implementation built on top of the
@@ -21,9 +44,18 @@ type queueManager struct {
 // require some locking to perform the underlying operations. The performance of
 // these operations will degrade with the number of jobs that the queue
 // contains, so best practice is to pass contexts with timeouts to all methods.
-func NewQueueManager(q amboy.Queue) Manager {
+//
+// opts is optional; if omitted, QueueManagerOptions defaults are used.
+func NewQueueManager(q amboy.Queue, opts ...QueueManagerOptions) Manager {
+	var o QueueManagerOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.resolve()
+
 	return &queueManager{
 		queue: q,
+		opts:  o,
 	}
 }
 
@@ -32,12 +64,23 @@ func (m *queueManager) JobStatus(ctx context.Context, f StatusFilter) (*JobStatu
 		return nil, errors.Wrap(err, "invalid filter")
 	}
 
-	counters := map[string]int{}
-	for info := range m.queue.JobInfo(ctx) {
+	shards := make([]map[string]int, m.opts.Concurrency)
+	for i := range shards {
+		shards[i] = map[string]int{}
+	}
+
+	forEachJobInfo(ctx, m.queue.JobInfo(ctx), m.opts.Concurrency, func(shard int, info amboy.JobInfo) {
 		if !m.matchesStatusFilter(info, f) {
-			continue
+			return
+		}
+		shards[shard][info.Type.Name]++
+	})
+
+	counters := map[string]int{}
+	for _, shard := range shards {
+		for jt, num := range shard {
+			counters[jt] += num
 		}
-		counters[info.Type.Name]++
 	}
 
 	out := JobStatusReport{}
@@ -65,33 +108,46 @@ func (m *queueManager) RecentTiming(ctx context.Context, window time.Duration, f
 		return nil, errors.New("must specify windows greater than one second")
 	}
 
-	counters := map[string][]time.Duration{}
+	if f != Running && f != Latency && f != Duration {
+		return nil, errors.New("invalid job runtime filter")
+	}
 
-	for info := range m.queue.JobInfo(ctx) {
+	shards := make([]map[string][]time.Duration, m.opts.Concurrency)
+	for i := range shards {
+		shards[i] = map[string][]time.Duration{}
+	}
+
+	forEachJobInfo(ctx, m.queue.JobInfo(ctx), m.opts.Concurrency, func(shard int, info amboy.JobInfo) {
+		counters := shards[shard]
 		switch f {
 		case Running:
 			if !info.Status.InProgress {
-				continue
+				return
 			}
 			counters[info.Type.Name] = append(counters[info.Type.Name], time.Since(info.Time.Start))
 		case Latency:
 			if info.Status.Completed {
-				continue
+				return
 			}
 			if time.Since(info.Time.Created) > window {
-				continue
+				return
 			}
 			counters[info.Type.Name] = append(counters[info.Type.Name], time.Since(info.Time.Created))
 		case Duration:
 			if !info.Status.Completed {
-				continue
+				return
 			}
 			if time.Since(info.Time.End) > window {
-				continue
+				return
 			}
 			counters[info.Type.Name] = append(counters[info.Type.Name], info.Time.End.Sub(info.Time.Start))
-		default:
-			return nil, errors.New("invalid job runtime filter")
+		}
+	})
+
+	counters := map[string][]time.Duration{}
+	for _, shard := range shards {
+		for jt, durations := range shard {
+			counters[jt] = append(counters[jt], durations...)
 		}
 	}
 
@@ -122,17 +178,28 @@ func (m *queueManager) JobIDsByState(ctx context.Context, jobType string, f Stat
 		return nil, errors.Wrap(err, "invalid filter")
 	}
 
-	uniqueIDs := map[string]struct{}{}
-	for info := range m.queue.JobInfo(ctx) {
+	shards := make([]map[string]struct{}, m.opts.Concurrency)
+	for i := range shards {
+		shards[i] = map[string]struct{}{}
+	}
+
+	forEachJobInfo(ctx, m.queue.JobInfo(ctx), m.opts.Concurrency, func(shard int, info amboy.JobInfo) {
 		if info.Type.Name != jobType {
-			continue
+			return
 		}
 
 		if !m.matchesStatusFilter(info, f) {
-			continue
+			return
 		}
 
-		uniqueIDs[info.ID] = struct{}{}
+		shards[shard][info.ID] = struct{}{}
+	})
+
+	uniqueIDs := map[string]struct{}{}
+	for _, shard := range shards {
+		for id := range shard {
+			uniqueIDs[id] = struct{}{}
+		}
 	}
 
 	ids := make([]GroupedID, 0, len(uniqueIDs))
@@ -178,37 +245,59 @@ func (m *queueManager) RecentErrors(ctx context.Context, window time.Duration, f
 		return nil, errors.New("must specify windows greater than one second")
 	}
 
-	collector := map[string]JobErrorsForType{}
+	if f != AllErrors && f != UniqueErrors && f != StatsOnly {
+		return nil, errors.New("operation is not supported")
+	}
 
-	for info := range m.queue.JobInfo(ctx) {
+	shards := make([]map[string]JobErrorsForType, m.opts.Concurrency)
+	for i := range shards {
+		shards[i] = map[string]JobErrorsForType{}
+	}
+
+	forEachJobInfo(ctx, m.queue.JobInfo(ctx), m.opts.Concurrency, func(shard int, info amboy.JobInfo) {
 		if !info.Status.Completed {
-			continue
+			return
 		}
 
 		if info.Status.ErrorCount == 0 {
-			continue
+			return
 		}
 
 		if time.Since(info.Time.End) > window {
-			continue
+			return
 		}
 
+		collector := shards[shard]
 		switch f {
 		case AllErrors, UniqueErrors:
 			val := collector[info.Type.Name]
 			val.Count++
 			val.Total += info.Status.ErrorCount
-			val.Errors = append(val.Errors, info.Status.Errors...)
+			if msg, ok := m.feedbackErrorFor(ctx, info.ID); ok {
+				val.Errors = append(val.Errors, msg)
+			} else {
+				val.Errors = append(val.Errors, info.Status.Errors...)
+			}
 			collector[info.Type.Name] = val
 		case StatsOnly:
 			val := collector[info.Type.Name]
 			val.Count++
 			val.Total += info.Status.ErrorCount
 			collector[info.Type.Name] = val
-		default:
-			return nil, errors.New("operation is not supported")
+		}
+	})
+
+	collector := map[string]JobErrorsForType{}
+	for _, shard := range shards {
+		for jt, val := range shard {
+			agg := collector[jt]
+			agg.Count += val.Count
+			agg.Total += val.Total
+			agg.Errors = append(agg.Errors, val.Errors...)
+			collector[jt] = agg
 		}
 	}
+
 	if f == UniqueErrors {
 		for k, v := range collector {
 			errs := map[string]struct{}{}
@@ -252,35 +341,57 @@ func (m *queueManager) RecentJobErrors(ctx context.Context, jobType string, wind
 		return nil, errors.New("must specify windows greater than one second")
 	}
 
-	collector := map[string]JobErrorsForType{}
+	if f != AllErrors && f != UniqueErrors && f != StatsOnly {
+		return nil, errors.New("operation is not supported")
+	}
 
-	for info := range m.queue.JobInfo(ctx) {
+	shards := make([]map[string]JobErrorsForType, m.opts.Concurrency)
+	for i := range shards {
+		shards[i] = map[string]JobErrorsForType{}
+	}
+
+	forEachJobInfo(ctx, m.queue.JobInfo(ctx), m.opts.Concurrency, func(shard int, info amboy.JobInfo) {
 		if !info.Status.Completed || info.Status.ErrorCount == 0 {
-			continue
+			return
 		}
 		if time.Since(info.Time.End) > window {
-			continue
+			return
 		}
 		if info.Type.Name != jobType {
-			continue
+			return
 		}
 
+		collector := shards[shard]
 		switch f {
 		case AllErrors, UniqueErrors:
 			val := collector[info.Type.Name]
 			val.Count++
 			val.Total += info.Status.ErrorCount
-			val.Errors = append(val.Errors, info.Status.Errors...)
+			if msg, ok := m.feedbackErrorFor(ctx, info.ID); ok {
+				val.Errors = append(val.Errors, msg)
+			} else {
+				val.Errors = append(val.Errors, info.Status.Errors...)
+			}
 			collector[info.Type.Name] = val
 		case StatsOnly:
 			val := collector[info.Type.Name]
 			val.Count++
 			val.Total += info.Status.ErrorCount
 			collector[info.Type.Name] = val
-		default:
-			return nil, errors.New("operation is not supported")
+		}
+	})
+
+	collector := map[string]JobErrorsForType{}
+	for _, shard := range shards {
+		for jt, val := range shard {
+			agg := collector[jt]
+			agg.Count += val.Count
+			agg.Total += val.Total
+			agg.Errors = append(agg.Errors, val.Errors...)
+			collector[jt] = agg
 		}
 	}
+
 	if f == UniqueErrors {
 		for k, v := range collector {
 			errs := map[string]struct{}{}
@@ -358,6 +469,7 @@ func (m *queueManager) CompleteJobsByType(ctx context.Context, f StatusFilter, j
 		return errors.Wrap(err, "invalid filter")
 	}
 
+	completer := NewBatchCompleter(m.queue, BatchCompleterOptions{})
 	catcher := grip.NewBasicCatcher()
 	for info := range m.queue.JobInfo(ctx) {
 		if info.Type.Name != jobType {
@@ -374,31 +486,45 @@ func (m *queueManager) CompleteJobsByType(ctx context.Context, f StatusFilter, j
 			continue
 		}
 
-		catcher.Wrapf(m.completeJob(ctx, j), "marking job '%s' complete", j.ID())
+		catcher.Add(completer.Add(ctx, j))
 	}
+	catcher.Add(completer.Flush(ctx))
 
 	return catcher.Resolve()
 }
 
 func (m *queueManager) completeJob(ctx context.Context, j amboy.Job) error {
-	if err := m.queue.Complete(ctx, j); err != nil {
+	return completeJobAndRetry(ctx, m.queue, j)
+}
+
+// completeJobAndRetry marks a single job complete against the given queue,
+// additionally marking it complete in the queue's retry metadata if the
+// queue is retryable. This is the same per-job completion logic used by
+// queueManager.completeJob and by BatchCompleter's fallback path, so both
+// share identical semantics for what counts as "complete".
+func completeJobAndRetry(ctx context.Context, q amboy.Queue, j amboy.Job) error {
+	if err := q.Complete(ctx, j); err != nil {
 		return errors.Wrap(err, "completing job")
 	}
 
 	var err error
-	amboy.WithRetryableQueue(m.queue, func(rq amboy.RetryableQueue) {
+	amboy.WithRetryableQueue(q, func(rq amboy.RetryableQueue) {
 		err = rq.CompleteRetrying(ctx, j)
 	})
 
 	return errors.Wrap(err, "marking retryable job as complete")
 }
 
-// CompleteJobs marks all jobs complete that match the status filter.
+// CompleteJobs marks all jobs complete that match the status filter. Jobs
+// are routed through a BatchCompleter so that queues which support bulk
+// completion only pay for one round trip per batch, rather than one per
+// job.
 func (m *queueManager) CompleteJobs(ctx context.Context, f StatusFilter) error {
 	if err := f.Validate(); err != nil {
 		return errors.Wrap(err, "invalid filter")
 	}
 
+	completer := NewBatchCompleter(m.queue, BatchCompleterOptions{})
 	catcher := grip.NewBasicCatcher()
 	for info := range m.queue.JobInfo(ctx) {
 		if !m.matchesStatusFilter(info, f) {
@@ -411,8 +537,9 @@ func (m *queueManager) CompleteJobs(ctx context.Context, f StatusFilter) error {
 			continue
 		}
 
-		catcher.Wrapf(m.completeJob(ctx, j), "marking job '%s' complete", j.ID())
+		catcher.Add(completer.Add(ctx, j))
 	}
+	catcher.Add(completer.Flush(ctx))
 
 	return catcher.Resolve()
 }
@@ -431,6 +558,7 @@ func (m *queueManager) CompleteJobsByPattern(ctx context.Context, f StatusFilter
 		return errors.Wrap(err, "invalid regexp")
 	}
 
+	completer := NewBatchCompleter(m.queue, BatchCompleterOptions{})
 	catcher := grip.NewBasicCatcher()
 	for info := range m.queue.JobInfo(ctx) {
 		if !regex.MatchString(info.ID) {
@@ -447,8 +575,9 @@ func (m *queueManager) CompleteJobsByPattern(ctx context.Context, f StatusFilter
 			continue
 		}
 
-		catcher.Wrapf(m.completeJob(ctx, j), "marking job '%s' complete", j.ID())
+		catcher.Add(completer.Add(ctx, j))
 	}
+	catcher.Add(completer.Flush(ctx))
 
 	return catcher.Resolve()
 }
@@ -0,0 +1,341 @@
+package management
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// defaultSchedulerPollInterval is how often a Scheduler checks its
+// registered schedules for due ticks. NewScheduler always uses this
+// interval.
+const defaultSchedulerPollInterval = 15 * time.Second
+
+// maxSchedulerCatchUp bounds how far in the past runDueTicks will start
+// backfilling ticks for a schedule, whether it's falling behind because of
+// a StartAt set long ago or because the scheduler itself was stopped for a
+// while. Ticks older than this are skipped rather than backfilled, so a
+// schedule registered with a stale StartAt can't force a single tick to
+// enqueue thousands of jobs before it returns.
+const maxSchedulerCatchUp = 24 * time.Hour
+
+// maxSchedulerCatchUpTicks additionally bounds how many ticks a single
+// runDueTicks call will enqueue, so even within the lookback window above a
+// short enough interval still can't flood the queue in one poll. Any ticks
+// left over are picked up on the next poll.
+const maxSchedulerCatchUpTicks = 1000
+
+// ScheduleSpec describes when a schedule should fire. Exactly one of Cron
+// or Interval should be set; Cron takes precedence if both are set. StartAt
+// and EndAt, if non-zero, bound the window in which the schedule is active.
+type ScheduleSpec struct {
+	Cron     string
+	Interval time.Duration
+	StartAt  time.Time
+	EndAt    time.Time
+}
+
+func (s ScheduleSpec) validate() error {
+	if s.Cron == "" && s.Interval <= 0 {
+		return errors.New("must specify either a cron expression or a positive interval")
+	}
+	if s.Cron != "" {
+		if _, err := parseCronSpec(s.Cron); err != nil {
+			return errors.Wrap(err, "invalid cron expression")
+		}
+	}
+	if !s.StartAt.IsZero() && !s.EndAt.IsZero() && !s.EndAt.After(s.StartAt) {
+		return errors.New("EndAt must be after StartAt")
+	}
+	return nil
+}
+
+// next returns the next tick strictly after after, or false if the
+// schedule has no more ticks, either because EndAt has passed or because a
+// cron expression could not produce one within its lookahead window.
+func (s ScheduleSpec) next(after time.Time) (time.Time, bool) {
+	if !s.StartAt.IsZero() && after.Before(s.StartAt) {
+		after = s.StartAt.Add(-time.Nanosecond)
+	}
+
+	var t time.Time
+	if s.Cron != "" {
+		spec, err := parseCronSpec(s.Cron)
+		if err != nil {
+			return time.Time{}, false
+		}
+		t = spec.next(after)
+		if t.IsZero() {
+			return time.Time{}, false
+		}
+	} else {
+		t = after.Add(s.Interval)
+	}
+
+	if !s.EndAt.IsZero() && t.After(s.EndAt) {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// PersistedSchedule is the serializable form of a registered schedule,
+// suitable for an operator to save to their own store and replay via
+// ImportSchedules after a restart. The job factory itself can't be
+// serialized, so callers must supply it again at import time.
+type PersistedSchedule struct {
+	ID   string
+	Spec ScheduleSpec
+}
+
+type schedule struct {
+	id      string
+	spec    ScheduleSpec
+	factory func() amboy.Job
+	lastRun time.Time
+}
+
+// Scheduler runs registered schedules against a queue, enqueueing a new job
+// for each tick that is due. Schedules may be defined with a cron
+// expression or a fixed interval, plus optional start/end bounds.
+//
+// To avoid double-enqueueing a tick across multiple processes sharing the
+// same queue, Scheduler derives a deterministic job ID from the schedule ID
+// and tick time and checks the queue for that ID before enqueueing.
+type Scheduler struct {
+	queue amboy.Queue
+
+	mu        sync.Mutex
+	schedules map[string]*schedule
+
+	pollInterval time.Duration
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	started      bool
+}
+
+// NewScheduler constructs a Scheduler backed by the given queue.
+func NewScheduler(q amboy.Queue) *Scheduler {
+	return &Scheduler{
+		queue:        q,
+		schedules:    map[string]*schedule{},
+		pollInterval: defaultSchedulerPollInterval,
+	}
+}
+
+// RegisterSchedule registers a schedule under id, replacing any existing
+// schedule with the same id. factory is invoked once per due tick to
+// produce the job that gets enqueued.
+func (s *Scheduler) RegisterSchedule(id string, spec ScheduleSpec, factory func() amboy.Job) error {
+	if id == "" {
+		return errors.New("schedule id must not be empty")
+	}
+	if factory == nil {
+		return errors.New("factory must not be nil")
+	}
+	if err := spec.validate(); err != nil {
+		return errors.Wrap(err, "invalid schedule spec")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedules[id] = &schedule{id: id, spec: spec, factory: factory}
+
+	return nil
+}
+
+// ExportSchedules returns the current schedule definitions in a form an
+// operator can persist to their own store (e.g. a config collection).
+func (s *Scheduler) ExportSchedules() []PersistedSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]PersistedSchedule, 0, len(s.schedules))
+	for id, sched := range s.schedules {
+		out = append(out, PersistedSchedule{ID: id, Spec: sched.spec})
+	}
+
+	return out
+}
+
+// ImportSchedules re-registers schedules previously returned by
+// ExportSchedules, typically on startup after loading them back from
+// persistent storage. factories must contain an entry for every schedule ID
+// in specs; schedules with no matching factory are reported as errors but
+// do not prevent the rest from being imported.
+func (s *Scheduler) ImportSchedules(specs []PersistedSchedule, factories map[string]func() amboy.Job) error {
+	catcher := grip.NewBasicCatcher()
+	for _, ps := range specs {
+		factory, ok := factories[ps.ID]
+		if !ok {
+			catcher.Errorf("no factory provided for schedule '%s'", ps.ID)
+			continue
+		}
+		catcher.Add(s.RegisterSchedule(ps.ID, ps.Spec, factory))
+	}
+
+	return catcher.Resolve()
+}
+
+// Start begins polling registered schedules on top of the given context.
+// Start is a no-op if the scheduler is already running; only one process
+// sharing a queue needs to run its Scheduler for ticks to be enqueued, but
+// it is safe for several to run concurrently since ticks are deduplicated
+// by job ID.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.started = true
+
+	s.wg.Add(1)
+	go s.run(runCtx)
+
+	return nil
+}
+
+// Close stops the scheduler and waits for the in-flight tick, if any, to
+// finish or for ctx to be done, whichever happens first.
+func (s *Scheduler) Close(ctx context.Context) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+		s.started = false
+	}
+	s.mu.Unlock()
+
+	wait := make(chan struct{})
+	go func() {
+		defer close(wait)
+		s.wg.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-wait:
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(s.pollInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			s.tick(ctx)
+			timer.Reset(s.pollInterval)
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	s.mu.Lock()
+	schedules := make([]*schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		schedules = append(schedules, sched)
+	}
+	s.mu.Unlock()
+
+	for _, sched := range schedules {
+		s.runDueTicks(ctx, sched, now)
+	}
+}
+
+// runDueTicks enqueues every tick of sched that fell between its last run
+// and now, so that a slow poll interval doesn't silently skip ticks.
+func (s *Scheduler) runDueTicks(ctx context.Context, sched *schedule, now time.Time) {
+	last := sched.lastRun
+	if last.IsZero() {
+		last = sched.spec.StartAt
+	}
+
+	if oldest := now.Add(-maxSchedulerCatchUp); last.Before(oldest) {
+		// Neither a zero StartAt/lastRun nor one set further back than
+		// maxSchedulerCatchUp gives us a usable starting point; anchor
+		// to the lookback bound instead; otherwise the catch-up loop
+		// below would walk forward one tick at a time from the zero
+		// time or from however long ago StartAt/lastRun was.
+		last = oldest
+		if sched.spec.Cron == "" {
+			// Snap the anchor back onto the schedule's own interval grid
+			// (counted from StartAt, or from the zero time if StartAt is
+			// unset), so every process sharing the queue derives the same
+			// tick times, and so the same job IDs, whenever it polls.
+			if sched.spec.StartAt.IsZero() {
+				last = oldest.Truncate(sched.spec.Interval)
+			} else {
+				last = sched.spec.StartAt.Add(oldest.Sub(sched.spec.StartAt) / sched.spec.Interval * sched.spec.Interval)
+			}
+		}
+	}
+
+	for ticks := 0; ticks < maxSchedulerCatchUpTicks; ticks++ {
+		next, ok := sched.spec.next(last)
+		if !ok || next.After(now) {
+			return
+		}
+
+		id := deterministicScheduleJobID(sched.id, next)
+		if _, exists := s.queue.Get(ctx, id); !exists {
+			j := sched.factory()
+			j.SetID(id)
+
+			if err := s.queue.Put(ctx, j); err != nil {
+				grip.Error(message.WrapError(err, message.Fields{
+					"message":  "failed to enqueue scheduled job",
+					"schedule": sched.id,
+					"tick":     next,
+				}))
+				return
+			}
+		}
+
+		sched.lastRun = next
+		last = next
+	}
+
+	grip.Warning(message.Fields{
+		"message":  "schedule has more due ticks than the per-poll catch-up cap; remaining ticks will be picked up on a later poll",
+		"schedule": sched.id,
+		"cap":      maxSchedulerCatchUpTicks,
+	})
+}
+
+// deterministicScheduleJobID returns the ID used to detect whether tick t
+// of schedule id has already been enqueued, whether by this process or
+// another one sharing the same queue.
+func deterministicScheduleJobID(id string, t time.Time) string {
+	return fmt.Sprintf("sched:%s:%d", id, t.Unix())
+}
+
+// EnqueueAt adds j to the queue stamped with a WaitUntil time. The job
+// becomes eligible to run once the pool's worker loop observes that
+// WaitUntil has passed; see pool.abortablePool's worker loop.
+func (m *queueManager) EnqueueAt(ctx context.Context, j amboy.Job, runAt time.Time) error {
+	info := j.TimeInfo()
+	info.WaitUntil = runAt
+	j.UpdateTimeInfo(info)
+
+	return errors.Wrap(m.queue.Put(ctx, j), "enqueueing job")
+}
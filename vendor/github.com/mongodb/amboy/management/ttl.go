@@ -0,0 +1,252 @@
+package management
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// DefaultTTLReaperInterval is how often a TTLReaper scans the queue for
+// expired jobs, when the caller does not specify an interval.
+const DefaultTTLReaperInterval = time.Minute
+
+// DefaultTTLReaperMaxPerTick bounds the number of jobs a TTLReaper will
+// prune in a single tick, when the caller does not specify one.
+const DefaultTTLReaperMaxPerTick = 1000
+
+// TTLReaperOptions configure how long finished jobs are retained before
+// TTLReaper marks them complete and eligible for removal.
+type TTLReaperOptions struct {
+	// DefaultTTL is applied to any completed job that does not have a
+	// more specific TTL below.
+	DefaultTTL time.Duration
+	// TypeTTLs overrides DefaultTTL for specific job types.
+	TypeTTLs map[string]time.Duration
+	// ErrorTTL, if set, overrides DefaultTTL for jobs that completed
+	// with at least one error, so that failures can be retained longer
+	// than successes for debugging.
+	ErrorTTL time.Duration
+	// Interval is how often the reaper scans the queue. Defaults to
+	// DefaultTTLReaperInterval.
+	Interval time.Duration
+	// MaxPerTick bounds how many jobs are pruned in a single scan, to
+	// avoid holding the queue's lock for too long. Defaults to
+	// DefaultTTLReaperMaxPerTick.
+	MaxPerTick int
+}
+
+func (o *TTLReaperOptions) resolve() {
+	if o.Interval <= 0 {
+		o.Interval = DefaultTTLReaperInterval
+	}
+	if o.MaxPerTick <= 0 {
+		o.MaxPerTick = DefaultTTLReaperMaxPerTick
+	}
+}
+
+// ttlFor resolves the TTL that applies to a completed job of the given
+// type, preferring a per-type override, then an error-specific TTL, and
+// falling back to the default.
+func (o *TTLReaperOptions) ttlFor(jobType string, hadError bool) time.Duration {
+	if ttl, ok := o.TypeTTLs[jobType]; ok {
+		return ttl
+	}
+	if hadError && o.ErrorTTL > 0 {
+		return o.ErrorTTL
+	}
+	return o.DefaultTTL
+}
+
+// TTLReaper periodically scans a queue's JobInfo for jobs that finished
+// more than their configured TTL ago and marks them complete, borrowing the
+// ttlSecondsAfterFinished pattern from Kubernetes-style job controllers.
+// It never reaps a job that still needs a retry.
+type TTLReaper struct {
+	queue amboy.Queue
+	opts  TTLReaperOptions
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+// NewTTLReaper constructs a TTLReaper for the given queue. It must be
+// started with Start before it prunes anything.
+func NewTTLReaper(q amboy.Queue, opts TTLReaperOptions) *TTLReaper {
+	opts.resolve()
+
+	return &TTLReaper{
+		queue: q,
+		opts:  opts,
+	}
+}
+
+// Start begins periodically scanning and pruning jobs on top of the given
+// context. Start is a no-op if the reaper is already running.
+func (r *TTLReaper) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.started = true
+
+	r.wg.Add(1)
+	go r.run(runCtx)
+
+	return nil
+}
+
+// Close stops the reaper and waits for the in-flight tick, if any, to
+// finish or for ctx to be done, whichever happens first.
+func (r *TTLReaper) Close(ctx context.Context) {
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.cancel()
+		r.cancel = nil
+		r.started = false
+	}
+	r.mu.Unlock()
+
+	wait := make(chan struct{})
+	go func() {
+		defer close(wait)
+		r.wg.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-wait:
+	}
+}
+
+func (r *TTLReaper) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	timer := time.NewTimer(r.opts.Interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			n, err := r.tick(ctx)
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "ttl reaper tick encountered errors",
+			}))
+			grip.InfoWhen(n > 0, message.Fields{
+				"message": "ttl reaper pruned jobs",
+				"count":   n,
+			})
+			timer.Reset(r.opts.Interval)
+		}
+	}
+}
+
+func (r *TTLReaper) tick(ctx context.Context) (int, error) {
+	catcher := grip.NewBasicCatcher()
+	byType := map[string]int{}
+	pruned := 0
+
+	// JobInfo streams from a producer goroutine that outlives this call
+	// unless something drains or cancels it. Give this tick its own
+	// sub-context so that stopping early at MaxPerTick, below, cancels
+	// the producer instead of leaking it for the life of the reaper.
+	tickCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for info := range r.queue.JobInfo(tickCtx) {
+		if pruned >= r.opts.MaxPerTick {
+			cancel()
+			break
+		}
+
+		if !info.Status.Completed || info.Retry.NeedsRetry {
+			continue
+		}
+
+		ttl := r.opts.ttlFor(info.Type.Name, info.Status.ErrorCount > 0)
+		if ttl <= 0 || time.Since(info.Time.End) < ttl {
+			continue
+		}
+
+		j, ok := r.queue.Get(ctx, info.ID)
+		if !ok {
+			continue
+		}
+
+		if err := r.queue.Complete(ctx, j); err != nil {
+			catcher.Wrapf(err, "pruning job '%s'", info.ID)
+			continue
+		}
+
+		pruned++
+		byType[info.Type.Name]++
+	}
+
+	for jt, count := range byType {
+		grip.Info(message.Fields{
+			"message": "pruned jobs past ttl",
+			"type":    jt,
+			"count":   count,
+		})
+	}
+
+	return pruned, catcher.Resolve()
+}
+
+// PruneJobs marks jobs matching the given status filter complete if they
+// finished more than olderThan ago, applying the same never-reap-a-pending-
+// retry rule as TTLReaper. It exists so operators can trigger a one-shot
+// cleanup without standing up a TTLReaper.
+func (m *queueManager) PruneJobs(ctx context.Context, f StatusFilter, olderThan time.Duration) (int, error) {
+	if err := f.Validate(); err != nil {
+		return 0, errors.Wrap(err, "invalid filter")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	pruned := 0
+	for info := range m.queue.JobInfo(ctx) {
+		if !m.matchesStatusFilter(info, f) {
+			continue
+		}
+
+		if info.Retry.NeedsRetry {
+			continue
+		}
+
+		if !info.Status.Completed {
+			continue
+		}
+
+		if time.Since(info.Time.End) < olderThan {
+			continue
+		}
+
+		j, err := m.getJob(ctx, info)
+		if err != nil {
+			catcher.Wrapf(err, "getting job '%s' from info", info.ID)
+			continue
+		}
+
+		if err := m.completeJob(ctx, j); err != nil {
+			catcher.Wrapf(err, "pruning job '%s'", info.ID)
+			continue
+		}
+
+		pruned++
+	}
+
+	return pruned, catcher.Resolve()
+}
@@ -20,6 +20,24 @@ type abortablePool struct {
 	canceler context.CancelFunc
 	queue    amboy.Queue
 	jobs     map[string]context.CancelFunc
+
+	// deps maps a parent job ID to the IDs of its declared dependents,
+	// so that aborting the parent cascades to them. parentOf is the
+	// reverse lookup, used by the worker loop to tell whether a job it
+	// just dequeued is a declared child of a job that has since been
+	// aborted. aborted records every job ID that has been aborted,
+	// whether directly or as part of a cascade.
+	deps     map[string][]string
+	parentOf map[string]string
+	aborted  map[string]struct{}
+
+	// feedback holds the feedback sink for every FeedbackJob this pool
+	// has run, keyed by job ID, so that a Manager can read or tail it
+	// after the fact via JobFeedback/StreamJobFeedback. feedbackOrder
+	// tracks insertion order so the oldest entries can be evicted once
+	// maxTrackedJobFeedback is exceeded.
+	feedback      map[string]*feedbackSink
+	feedbackOrder []string
 }
 
 // NewAbortablePool produces a simple implementation of a worker pool
@@ -28,9 +46,13 @@ type abortablePool struct {
 // canceling the contexts passed to the jobs specifically.
 func NewAbortablePool(size int, q amboy.Queue) amboy.AbortableRunner {
 	p := &abortablePool{
-		queue: q,
-		size:  size,
-		jobs:  map[string]context.CancelFunc{},
+		queue:    q,
+		size:     size,
+		jobs:     map[string]context.CancelFunc{},
+		deps:     map[string][]string{},
+		parentOf: map[string]string{},
+		aborted:  map[string]struct{}{},
+		feedback: map[string]*feedbackSink{},
 	}
 
 	if p.size <= 0 {
@@ -163,6 +185,36 @@ func (p *abortablePool) worker(bctx context.Context) {
 				continue
 			}
 
+			if waitUntil := job.TimeInfo().WaitUntil; waitUntil.After(time.Now()) {
+				// Next has already dispatched (and, for a remote queue,
+				// locked) this job, and Put only inserts new jobs — it
+				// can't hand an already-dispatched job back to the queue.
+				// Hold onto it and wait out the remaining delay ourselves
+				// instead, so it isn't lost or spun on forever
+				// re-dispatching the same job.
+				delayTimer := time.NewTimer(time.Until(waitUntil))
+				select {
+				case <-bctx.Done():
+					delayTimer.Stop()
+					return
+				case <-delayTimer.C:
+				}
+			}
+
+			if parent, ok := p.parentAborted(job.ID()); ok {
+				job.AddError(errors.Errorf("aborted: parent '%s' cancelled", parent))
+				grip.Error(message.WrapError(p.queue.Complete(bctx, job), message.Fields{
+					"message":  "could not mark aborted dependent job complete",
+					"job_id":   job.ID(),
+					"queue_id": p.queue.ID(),
+				}))
+				// This job never reaches runJob, so its defer never runs;
+				// clean up here instead.
+				p.cleanupJobState(job.ID())
+				timer.Reset(baseJobInterval)
+				continue
+			}
+
 			ctx, cancel = context.WithCancel(bctx)
 			p.runJob(ctx, job)
 			cancel()
@@ -178,22 +230,198 @@ func (p *abortablePool) addCanceler(id string, cancel context.CancelFunc) {
 	p.jobs[id] = cancel
 }
 
+// cleanupJobState removes every per-job bookkeeping entry for id. It must
+// be called for every job this pool stops tracking, not only ones that run
+// to completion through runJob: a job skipped outright because its parent
+// was already aborted, or a descendant abortDescendants marks complete
+// directly because it never started running, leaves the same stale
+// parentOf/aborted entries behind if nothing cleans them up.
+func (p *abortablePool) cleanupJobState(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.jobs, id)
+	delete(p.deps, id)
+	delete(p.parentOf, id)
+	// A retryable job keeps the same ID across attempts, so a stale
+	// aborted marker here would permanently short-circuit future
+	// attempts as "aborted: parent cancelled" even though this run
+	// finished.
+	delete(p.aborted, id)
+}
+
 func (p *abortablePool) runJob(ctx context.Context, job amboy.Job) {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 
 	p.addCanceler(job.ID(), cancel)
 
+	var sink *feedbackSink
+	if fj, ok := job.(amboy.FeedbackJob); ok {
+		persist, _ := job.(amboy.FeedbackPersister)
+		sink = newFeedbackSink(defaultFeedbackBufferSize, persist)
+		p.trackFeedback(job.ID(), sink)
+		fj.SetFeedback(sink)
+	}
+
 	defer func() {
-		p.mu.Lock()
-		defer p.mu.Unlock()
+		p.cleanupJobState(job.ID())
 
-		delete(p.jobs, job.ID())
+		if sink != nil {
+			// Signal any StreamJobFeedback subscribers that no more
+			// entries are coming, rather than leaving them blocked
+			// until their ctx is done.
+			sink.close()
+		}
 	}()
 
 	executeJob(ctx, "abortable", job, p.queue)
 }
 
+// trackFeedback records sink as the feedback history for id, evicting the
+// oldest tracked job's history once maxTrackedJobFeedback is exceeded.
+func (p *abortablePool) trackFeedback(id string, sink *feedbackSink) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.feedback[id] = sink
+	p.feedbackOrder = append(p.feedbackOrder, id)
+
+	for len(p.feedbackOrder) > maxTrackedJobFeedback {
+		oldest := p.feedbackOrder[0]
+		p.feedbackOrder = p.feedbackOrder[1:]
+		delete(p.feedback, oldest)
+	}
+}
+
+// JobFeedback implements amboy.FeedbackProvider, returning every retained
+// feedback entry recorded for id at or after since. Only the most recent
+// defaultFeedbackBufferSize entries of each job are retained.
+func (p *abortablePool) JobFeedback(ctx context.Context, id string, since time.Time) ([]amboy.FeedbackEntry, error) {
+	p.mu.RLock()
+	sink, ok := p.feedback[id]
+	p.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.Errorf("no feedback recorded for job '%s'", id)
+	}
+
+	return sink.since(since), nil
+}
+
+// StreamJobFeedback implements amboy.FeedbackProvider, returning a channel
+// of feedback entries for id as they are recorded. The channel is closed
+// once ctx is done or, if sooner, once the job finishes.
+func (p *abortablePool) StreamJobFeedback(ctx context.Context, id string) (<-chan amboy.FeedbackEntry, error) {
+	p.mu.RLock()
+	sink, ok := p.feedback[id]
+	p.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.Errorf("no feedback recorded for job '%s'", id)
+	}
+
+	ch := sink.subscribe()
+	go func() {
+		<-ctx.Done()
+		sink.unsubscribe(ch)
+	}()
+
+	return ch, nil
+}
+
+// RegisterDependency declares that childID is a dependent of parentID, so
+// that aborting parentID also aborts and marks-complete childID, and
+// transitively, anything childID is itself a parent of.
+func (p *abortablePool) RegisterDependency(parentID, childID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.deps[parentID] = append(p.deps[parentID], childID)
+	p.parentOf[childID] = parentID
+}
+
+// parentAborted reports whether id is a declared child of a job that has
+// been aborted, returning the aborted parent's ID when true.
+func (p *abortablePool) parentAborted(id string) (string, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	parent, ok := p.parentOf[id]
+	if !ok {
+		return "", false
+	}
+
+	_, aborted := p.aborted[parent]
+	return parent, aborted
+}
+
+// descendantsLocked does a BFS over the dependency graph rooted at id and
+// returns every transitive child, marking id and each descendant as
+// aborted along the way so that the worker loop can recognize them even
+// before their Complete call lands. Callers must hold p.mu.
+func (p *abortablePool) descendantsLocked(id string) []string {
+	p.aborted[id] = struct{}{}
+
+	var out []string
+	seen := map[string]struct{}{}
+	pending := append([]string{}, p.deps[id]...)
+	for len(pending) > 0 {
+		child := pending[0]
+		pending = pending[1:]
+
+		if _, ok := seen[child]; ok {
+			continue
+		}
+		seen[child] = struct{}{}
+		p.aborted[child] = struct{}{}
+		out = append(out, child)
+
+		pending = append(pending, p.deps[child]...)
+	}
+
+	return out
+}
+
+// abortDescendants cancels any descendant that is currently running and
+// marks-complete any that have been enqueued but not yet picked up by a
+// worker, so that cascading an abort doesn't require every descendant to
+// already be running.
+func (p *abortablePool) abortDescendants(ctx context.Context, parentID string, descendants []string) error {
+	catcher := grip.NewBasicCatcher()
+
+	for _, childID := range descendants {
+		p.mu.Lock()
+		cancel, running := p.jobs[childID]
+		if running {
+			delete(p.jobs, childID)
+		}
+		p.mu.Unlock()
+
+		if running {
+			// The child is mid-run, so canceling it lets it unwind
+			// through runJob's own defer, which cleans up the rest of
+			// its state.
+			cancel()
+			continue
+		}
+
+		// The child never started running, so nothing will call
+		// runJob's defer for it; clean up its state here instead.
+		job, ok := p.queue.Get(ctx, childID)
+		if !ok {
+			p.cleanupJobState(childID)
+			continue
+		}
+
+		job.AddError(errors.Errorf("aborted: parent '%s' cancelled", parentID))
+		catcher.Wrapf(p.queue.Complete(ctx, job), "marking dependent job '%s' complete", childID)
+		p.cleanupJobState(childID)
+	}
+
+	return catcher.Resolve()
+}
+
 func (p *abortablePool) IsRunning(id string) bool {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -217,40 +445,60 @@ func (p *abortablePool) RunningJobs() []string {
 
 func (p *abortablePool) Abort(ctx context.Context, id string) error {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
 	cancel, ok := p.jobs[id]
 	if !ok {
+		p.mu.Unlock()
 		return errors.Errorf("job '%s' is not defined", id)
 	}
 	cancel()
 	delete(p.jobs, id)
+	descendants := p.descendantsLocked(id)
+	p.mu.Unlock()
 
 	job, ok := p.queue.Get(ctx, id)
 	if !ok {
 		return errors.Errorf("could not find '%s' in the queue", id)
 	}
 
-	return errors.Wrap(p.queue.Complete(ctx, job), "marking job complete")
+	catcher := grip.NewBasicCatcher()
+	catcher.Wrap(p.queue.Complete(ctx, job), "marking job complete")
+	catcher.Add(p.abortDescendants(ctx, id, descendants))
+
+	return catcher.Resolve()
 }
 
 func (p *abortablePool) AbortAll(ctx context.Context) error {
 	p.mu.Lock()
-	defer p.mu.Unlock()
+	ids := make([]string, 0, len(p.jobs))
+	for id := range p.jobs {
+		ids = append(ids, id)
+	}
+	p.mu.Unlock()
 
 	catcher := grip.NewBasicCatcher()
-	for id, cancel := range p.jobs {
+	for _, id := range ids {
 		if ctx.Err() != nil {
 			catcher.Add(ctx.Err())
 			break
 		}
+
+		p.mu.Lock()
+		cancel, ok := p.jobs[id]
+		if !ok {
+			p.mu.Unlock()
+			continue
+		}
 		cancel()
 		delete(p.jobs, id)
+		descendants := p.descendantsLocked(id)
+		p.mu.Unlock()
+
 		job, ok := p.queue.Get(ctx, id)
-		if !ok {
-			continue
+		if ok {
+			catcher.Wrapf(p.queue.Complete(ctx, job), "marking job '%s' complete", job.ID())
 		}
-		catcher.Wrapf(p.queue.Complete(ctx, job), "marking job '%s' complete", job.ID())
+
+		catcher.Add(p.abortDescendants(ctx, id, descendants))
 	}
 
 	return catcher.Resolve()
@@ -0,0 +1,118 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mongodb/amboy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitUntilFakeJob is a minimal amboy.Job used only to observe whether and
+// when the pool ran it.
+type waitUntilFakeJob struct {
+	id   string
+	info amboy.JobTimeInfo
+
+	mu  sync.Mutex
+	ran int
+}
+
+func (j *waitUntilFakeJob) ID() string                            { return j.id }
+func (j *waitUntilFakeJob) SetID(id string)                       { j.id = id }
+func (j *waitUntilFakeJob) Type() amboy.JobType                   { return amboy.JobType{Name: "wait-until-fake"} }
+func (j *waitUntilFakeJob) TimeInfo() amboy.JobTimeInfo           { return j.info }
+func (j *waitUntilFakeJob) UpdateTimeInfo(info amboy.JobTimeInfo) { j.info = info }
+func (j *waitUntilFakeJob) AddError(error)                        {}
+
+func (j *waitUntilFakeJob) Run(ctx context.Context) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.ran++
+}
+
+func (j *waitUntilFakeJob) runCount() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.ran
+}
+
+// waitUntilFakeQueue is a minimal, single-job amboy.Queue: Next hands out
+// its one job exactly once, and Put records a call rather than actually
+// re-adding anything, since the point of the test is to prove the pool
+// never needs to call it for a job Next already dispatched.
+type waitUntilFakeQueue struct {
+	mu       sync.Mutex
+	job      amboy.Job
+	taken    bool
+	putCalls int
+}
+
+func (q *waitUntilFakeQueue) ID() string { return "wait-until-fake-queue" }
+
+func (q *waitUntilFakeQueue) Next(ctx context.Context) amboy.Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.taken || q.job == nil {
+		return nil
+	}
+	q.taken = true
+
+	return q.job
+}
+
+func (q *waitUntilFakeQueue) Put(ctx context.Context, j amboy.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.putCalls++
+
+	return nil
+}
+
+func (q *waitUntilFakeQueue) Get(ctx context.Context, id string) (amboy.Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.job == nil || q.job.ID() != id {
+		return nil, false
+	}
+
+	return q.job, true
+}
+
+func (q *waitUntilFakeQueue) Complete(ctx context.Context, j amboy.Job) error { return nil }
+
+// TestWorkerRunsJobAfterWaitUntilDelay enqueues a job whose WaitUntil is a
+// short delay in the future and asserts the worker loop runs it once that
+// delay passes, instead of dropping it (the in-memory case) or spinning on
+// a Put call that queue.Put cannot satisfy for a job it already dispatched.
+func TestWorkerRunsJobAfterWaitUntilDelay(t *testing.T) {
+	delay := 150 * time.Millisecond
+	job := &waitUntilFakeJob{
+		id:   "wait-until-job",
+		info: amboy.JobTimeInfo{WaitUntil: time.Now().Add(delay)},
+	}
+	queue := &waitUntilFakeQueue{job: job}
+
+	pool := NewAbortablePool(1, queue)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	require.NoError(t, pool.Start(ctx))
+	defer pool.Close(ctx)
+
+	require.Eventually(t, func() bool {
+		return job.runCount() > 0
+	}, time.Second, 10*time.Millisecond, "job should have run after its WaitUntil delay elapsed")
+
+	assert.Equal(t, 1, job.runCount(), "job should only run once")
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	assert.Equal(t, 0, queue.putCalls, "a dispatched job should never be routed back through Put")
+}
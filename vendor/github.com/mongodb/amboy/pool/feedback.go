@@ -0,0 +1,151 @@
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mongodb/amboy"
+)
+
+// defaultFeedbackBufferSize bounds how many feedback entries are retained
+// per job. abortablePool uses this size for every job; it is not
+// configurable.
+const defaultFeedbackBufferSize = 100
+
+// maxTrackedJobFeedback bounds how many jobs' feedback histories an
+// abortablePool keeps around at once, evicting the oldest once the limit
+// is reached, so a long-lived pool doesn't grow unbounded over many runs.
+const maxTrackedJobFeedback = 10000
+
+// feedbackSink is a bounded, per-job ring buffer that implements
+// amboy.Feedback. Each recorded entry is also fanned out to any active
+// subscribers, so that StreamJobFeedback can tail a running job live.
+type feedbackSink struct {
+	mu          sync.Mutex
+	cap         int
+	entries     []amboy.FeedbackEntry
+	subscribers []chan amboy.FeedbackEntry
+	closed      bool
+
+	// persist, if set, receives a copy of every recorded entry so the
+	// job itself can retain its feedback history as part of its
+	// persisted state. See amboy.FeedbackPersister.
+	persist amboy.FeedbackPersister
+}
+
+func newFeedbackSink(capacity int, persist amboy.FeedbackPersister) *feedbackSink {
+	if capacity <= 0 {
+		capacity = defaultFeedbackBufferSize
+	}
+
+	return &feedbackSink{cap: capacity, persist: persist}
+}
+
+func (s *feedbackSink) record(level amboy.FeedbackLevel, message string) {
+	entry := amboy.FeedbackEntry{Level: level, Time: time.Now(), Message: message}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, entry)
+	if len(s.entries) > s.cap {
+		s.entries = s.entries[len(s.entries)-s.cap:]
+	}
+	subs := append([]chan amboy.FeedbackEntry{}, s.subscribers...)
+	persist := s.persist
+	s.mu.Unlock()
+
+	if persist != nil {
+		persist.AddFeedback(entry)
+	}
+
+	for _, sub := range subs {
+		select {
+		case sub <- entry:
+		default:
+			// A slow subscriber shouldn't block the job it's tailing; it
+			// will miss entries rather than stall execution.
+		}
+	}
+}
+
+func (s *feedbackSink) Info(message string)  { s.record(amboy.FeedbackLevelInfo, message) }
+func (s *feedbackSink) Warn(message string)  { s.record(amboy.FeedbackLevelWarn, message) }
+func (s *feedbackSink) Error(message string) { s.record(amboy.FeedbackLevelError, message) }
+
+// since returns every retained entry recorded at or after since.
+func (s *feedbackSink) since(since time.Time) []amboy.FeedbackEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]amboy.FeedbackEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		if !e.Time.Before(since) {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
+
+// lastError returns the most recently recorded error-level entry, if any.
+func (s *feedbackSink) lastError() (amboy.FeedbackEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if s.entries[i].Level == amboy.FeedbackLevelError {
+			return s.entries[i], true
+		}
+	}
+
+	return amboy.FeedbackEntry{}, false
+}
+
+func (s *feedbackSink) subscribe() chan amboy.FeedbackEntry {
+	ch := make(chan amboy.FeedbackEntry, defaultFeedbackBufferSize)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		// The job already finished; there's nothing left to tail, so
+		// hand back a channel that's already closed rather than one
+		// that would never receive anything.
+		close(ch)
+		return ch
+	}
+
+	s.subscribers = append(s.subscribers, ch)
+
+	return ch
+}
+
+func (s *feedbackSink) unsubscribe(ch chan amboy.FeedbackEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, sub := range s.subscribers {
+		if sub == ch {
+			s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+			close(ch)
+			return
+		}
+	}
+}
+
+// close closes every active subscriber channel, signalling that no further
+// feedback will be recorded because the job has finished. Subsequent
+// subscribe calls return an already-closed channel.
+func (s *feedbackSink) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	for _, sub := range s.subscribers {
+		close(sub)
+	}
+	s.subscribers = nil
+}
@@ -0,0 +1,30 @@
+package amboy
+
+import "context"
+
+// Runner describes a simple worker interface for executing jobs dispatched
+// by a Queue. Most callers use one of the implementations in the pool
+// package rather than implementing this directly.
+type Runner interface {
+	Started() bool
+	SetQueue(Queue) error
+	Start(context.Context) error
+	Close(context.Context)
+}
+
+// AbortableRunner is a Runner that additionally allows callers to cancel
+// specific in-progress jobs.
+type AbortableRunner interface {
+	Runner
+
+	IsRunning(string) bool
+	RunningJobs() []string
+	Abort(context.Context, string) error
+	AbortAll(context.Context) error
+
+	// RegisterDependency declares that childID is a dependent of
+	// parentID, so that aborting parentID also aborts and
+	// marks-complete childID, and transitively, anything childID is
+	// itself a parent of.
+	RegisterDependency(parentID, childID string)
+}